@@ -206,6 +206,13 @@ const DefaultMaxHeaderBytes = 1 << 20 // 1 MB
 // MaxIdleConnsPerHost.
 const DefaultMaxIdleConnsPerHost = 2
 
+// DefaultMaxInformationalResponses is the default value of Transport's
+// MaxInformationalResponses.
+
+// DefaultMaxInformationalResponses 是 Transport 的 MaxInformationalResponses
+// 字段的默认值。
+const DefaultMaxInformationalResponses = 5
+
 // Common HTTP methods.
 //
 // Unless otherwise noted, these are defined in RFC 7231 section 4.3.
@@ -448,6 +455,16 @@ var ErrNoLocation = errors.New("http: no Location header in response")
 // Transport.RegisterProtocol.
 var ErrSkipAltProtocol = errors.New("net/http: skip alternate protocol")
 
+// ErrTooManyInformationalResponses is returned by Transport when a
+// server sends more 1xx informational responses than allowed by
+// Transport.MaxInformationalResponses before the final response.
+// The connection is closed when this error is returned.
+
+// 当服务器在最终回复之前发送的 1xx 信息性回复超过
+// Transport.MaxInformationalResponses 所允许的数量时，Transport 会返回
+// ErrTooManyInformationalResponses，同时关闭该连接。
+var ErrTooManyInformationalResponses = &ProtocolError{"too many 1xx informational responses"}
+
 // ErrUseLastResponse can be returned by Client.CheckRedirect hooks to
 // control how redirects are processed. If returned, the next request
 // is not sent and the most recent response is returned with its body
@@ -521,6 +538,71 @@ type Client struct {
 	// which is to stop after 10 consecutive requests.
 	CheckRedirect func(req *Request, via []*Request) error
 
+	// PrepareRequest, if non-nil, is called just before each network
+	// attempt to send a request, including the initial request, every
+	// request made while following a redirect, and every resend
+	// performed internally by the Transport (such as resending an
+	// idempotent request after a reused connection was closed by the
+	// server). The arguments req and via are the request about to be
+	// sent and the requests made already, oldest first; via is empty
+	// for the initial request. On a resend, via is the same as for the
+	// attempt being retried: the failed attempt produced no response
+	// and is not added to via.
+	//
+	// The req passed to PrepareRequest is a copy owned by the Client,
+	// never the Request given to Do, so PrepareRequest may change it,
+	// for example to refresh an authorization token or to recompute a
+	// request signature. A PrepareRequest that replaces req.Body must
+	// also set req.ContentLength and req.GetBody to match the new
+	// body, since redirects and resends obtain a fresh body by calling
+	// GetBody.
+	//
+	// For a redirect, PrepareRequest is called after CheckRedirect has
+	// approved the upcoming request, so it sees the header fields
+	// copied from the previous request and may adjust them. For a
+	// resend, it is called again after the body has been rewound with
+	// GetBody. If PrepareRequest returns an error, the request is not
+	// sent and Do returns the error wrapped in a url.Error, along with
+	// the previous Response (with its Body closed), if any.
+	//
+	// The Client calls PrepareRequest itself once before each call to
+	// its Transport's RoundTrip method. The hook is also carried in the
+	// request's context, so a *Transport that receives the request,
+	// either directly or through RoundTripper wrappers that pass on the
+	// request or a copy made with WithContext of a context derived from
+	// it, calls PrepareRequest before each of its internal resends.
+	// Wrappers that drop the request's context lose those calls. A
+	// wrapper that retries by calling the wrapped RoundTripper's
+	// RoundTrip again does not cause additional calls; such a wrapper
+	// must re-prepare the request itself.
+
+	// PrepareRequest 若非 nil，则会在每次通过网络发送请求前被调用，包括初始
+	// 请求、跟随重定向时发出的每个请求，以及由 Transport 内部执行的每次重发（
+	// 例如在复用的连接被服务器关闭后重新发送幂等请求）。参数 req 为即将发送的
+	// 请求，via 为已发出的请求（按时间先后排列）；对于初始请求，via 为空。对于
+	// 重发，via 与被重试的那次尝试相同：失败的尝试没有产生回复，不会被加入 via。
+	//
+	// 传给 PrepareRequest 的 req 是 Client 持有的副本，绝不会是传给 Do 的
+	// Request，因此 PrepareRequest 可以修改它，例如刷新认证令牌或重新计算请求
+	// 签名。若 PrepareRequest 替换了 req.Body，则必须同时设置与新主体相符的
+	// req.ContentLength 和 req.GetBody，因为重定向和重发会通过调用 GetBody
+	// 获取新的主体。
+	//
+	// 对于重定向，PrepareRequest 在 CheckRedirect 批准即将发出的请求之后调用，
+	// 因此它能看到从上一个请求复制来的头域并对其进行调整。对于重发，它会在主体
+	// 经 GetBody 重置之后再次被调用。若 PrepareRequest 返回错误，该请求将不会
+	// 被发送，Do 会返回包装在 url.Error 中的该错误，若有的话还会同时返回上一个
+	// Response（其 Body 已关闭）。
+	//
+	// Client 会在每次调用其 Transport 的 RoundTrip 方法之前自行调用一次
+	// PrepareRequest。该钩子还会随请求的 context 一同传递，因此收到该请求的
+	// *Transport，无论是直接收到，还是经由传递该请求（或以其派生 context 调用
+	// WithContext 得到的副本）的 RoundTripper 包装器收到，都会在每次内部重发前
+	// 调用 PrepareRequest。丢弃请求 context 的包装器将失去这些调用。通过再次调用
+	// 被包装 RoundTripper 的 RoundTrip 方法进行重试的包装器不会引起额外的调用，
+	// 这样的包装器必须自行重新准备请求。
+	PrepareRequest func(req *Request, via []*Request) error
+
 	// Jar specifies the cookie jar.
 	// If Jar is nil, cookies are not sent in requests and ignored
 	// in responses.
@@ -1448,6 +1530,37 @@ type Transport struct {
 	//
 	// Zero means to use a default limit.
 	MaxResponseHeaderBytes int64
+
+	// MaxInformationalResponses specifies a limit on how many 1xx
+	// informational responses (such as "103 Early Hints") the
+	// Transport reads before the final response to a request. If the
+	// server sends more, the request fails with
+	// ErrTooManyInformationalResponses and the connection is closed.
+	//
+	// A 101 Switching Protocols response is treated as the final
+	// response and is never counted. For a request with an
+	// "Expect: 100-continue" header, the first 100 Continue response,
+	// which tells the Transport to send the body, is not counted
+	// either; any further 100 responses are.
+	//
+	// Zero means to use DefaultMaxInformationalResponses. A
+	// negative value means no counted 1xx responses are allowed;
+	// 101 responses and the 100 Continue that answers
+	// "Expect: 100-continue" are still accepted.
+
+	// MaxInformationalResponses 指定了 Transport 在读取请求的最终回复之前，
+	// 最多处理多少个 1xx 信息性回复（如 "103 Early Hints"）。若服务器发送的
+	// 数量超过该值，请求会以 ErrTooManyInformationalResponses 失败，且该连接
+	// 会被关闭。
+	//
+	// 101 Switching Protocols 回复被视为最终回复，不会被计数。对于带有
+	// "Expect: 100-continue" 头的请求，通知 Transport 发送主体的第一个
+	// 100 Continue 回复同样不计数；之后的 100 回复则会计数。
+	//
+	// 零值表示使用 DefaultMaxInformationalResponses。负值表示不允许任何计数的
+	// 1xx 回复；101 回复和应答 "Expect: 100-continue" 的 100 Continue 仍会被
+	// 接受。
+	MaxInformationalResponses int
 }
 
 // CanonicalHeaderKey returns the canonical format of the
@@ -1815,6 +1928,77 @@ func Post(url string, bodyType string, body io.Reader) (resp *Response, err erro
 // PostForm是对包变量DefaultClient的PostForm方法的包装。
 func PostForm(url string, data url.Values) (resp *Response, err error)
 
+// PrecompressedFileServer returns a handler that serves HTTP requests
+// with the contents of the file system rooted at root, like FileServer,
+// but prefers precompressed variants of regular files when the client
+// accepts them.
+//
+// Each encoding must be "gzip" or "br", and PrecompressedFileServer
+// panics if any other value is given. If none are given, "br" and
+// "gzip" are used.
+//
+// For a request for name, a file named name+".gz" (for gzip) or
+// name+".br" (for br) in root is a variant of name if its modification
+// time is not before that of name. Older siblings are stale and are
+// ignored entirely, as if they did not exist.
+//
+// An encoding is accepted if the request's Accept-Encoding header lists
+// it with a non-zero q-value, or lists "*" with a non-zero q-value and
+// does not list the encoding itself. An encoding listed with "q=0", or
+// not listed while "*" has "q=0", is not accepted. Among the accepted
+// encodings that have a variant, the first one in encodings is served;
+// the client's q-values only decide acceptance, not preference, so
+// with the default encodings "gzip;q=1, br;q=0.5" is served the br
+// variant. The variant is served with a Content-Encoding header naming
+// the encoding. If no accepted encoding has a variant, name itself is
+// served. The Content-Type is determined from the extension of name,
+// not of the precompressed file, or by sniffing the uncompressed file
+// if the extension is unknown.
+//
+// A precompressed variant is only served alongside the uncompressed
+// file: if name does not exist, the request is answered with
+// 404 Not Found even when name+".gz" or name+".br" exists.
+//
+// Responses for a name that has at least one variant always include
+// "Vary: Accept-Encoding", whether or not a variant was served.
+// Responses for a name whose only siblings are stale do not. Range and
+// conditional requests (If-Match, If-None-Match, If-Modified-Since,
+// etc.) are evaluated against the file actually served, so ranges are
+// byte offsets within the compressed content.
+//
+// Directory listings and index.html handling behave as in FileServer.
+
+// PrecompressedFileServer 返回一个使用 FileSystem 接口 root 提供文件访问服务的
+// HTTP 处理器，与 FileServer 类似，但会在客户端接受时优先提供普通文件的预压缩
+// 版本。
+//
+// encodings 的每一项必须为 "gzip" 或 "br"，若传入其它值，
+// PrecompressedFileServer 会 panic。若未指定，则使用 "br" 和 "gzip"。
+//
+// 对于名为 name 的文件请求，若 root 中名为 name+".gz"（对应 gzip）或
+// name+".br"（对应 br）的文件的修改时间不早于 name，则它是 name 的预压缩版本。
+// 比 name 旧的同名文件视为过期，会被完全忽略，如同不存在一样。
+//
+// 若请求的 Accept-Encoding 头以非零 q 值列出某种编码，或以非零 q 值列出 "*"
+// 且未单独列出该编码，则该编码被接受。以 "q=0" 列出的编码，或在 "*" 为 "q=0"
+// 时未被列出的编码，均视为不被接受。在存在预压缩版本的被接受编码中，提供
+// encodings 里排在最前面的那一个；客户端的 q 值只决定是否接受，不决定优先级，
+// 因此在使用默认 encodings 时，"gzip;q=1, br;q=0.5" 会得到 br 版本。提供预压缩
+// 版本时会设置指明该编码的 Content-Encoding 头。若没有任何被接受的编码存在预压缩
+// 版本，则提供 name 本身。Content-Type 根据 name 的扩展名而非预压缩文件的扩展名
+// 确定；若扩展名未知，则通过嗅探未压缩文件确定。
+//
+// 预压缩版本只会与未压缩文件一同提供：若 name 不存在，即使存在 name+".gz" 或
+// name+".br"，也会以 404 Not Found 回复该请求。
+//
+// 对于至少存在一个预压缩版本的 name，无论是否提供了预压缩版本，回复总会包含
+// "Vary: Accept-Encoding"；若 name 只有过期的同名文件，则回复不包含该头。范围
+// 请求和条件请求（If-Match、If-None-Match、If-Modified-Since 等）针对实际提供
+// 的文件进行判断，因此范围是压缩内容中的字节偏移。
+//
+// 目录列表和 index.html 的处理与 FileServer 相同。
+func PrecompressedFileServer(root FileSystem, encodings ...string) Handler
+
 // ProxyFromEnvironment returns the URL of the proxy to use for a
 // given request, as indicated by the environment variables
 // HTTP_PROXY, HTTPS_PROXY and NO_PROXY (or the lowercase versions